@@ -0,0 +1,90 @@
+// Package cache persists raw provider responses to disk so repeated
+// lookups (and rapid ctrl+r refreshes) can be served without hitting the
+// network, and so a last-known-good response is available when a
+// provider is unreachable.
+package cache
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// Entry is the on-disk record for a single cached response.
+type Entry struct {
+	Body         []byte    `json:"body"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CachedAt     time.Time `json:"cached_at"`
+}
+
+// Fresh reports whether the entry is still within its provider-supplied
+// freshness window.
+func (e *Entry) Fresh() bool {
+	return e != nil && !e.ExpiresAt.IsZero() && time.Now().Before(e.ExpiresAt)
+}
+
+var unsafeKeyChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// Dir returns the root cache directory, honoring $XDG_CACHE_HOME.
+func Dir() (string, error) {
+	base := os.Getenv("XDG_CACHE_HOME")
+	if base == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		base = filepath.Join(home, ".cache")
+	}
+	return filepath.Join(base, "charm-go-weather"), nil
+}
+
+func path(provider, key string) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	safeKey := unsafeKeyChars.ReplaceAllString(key, "_")
+	return filepath.Join(dir, provider, safeKey+".json"), nil
+}
+
+// Load reads the cached entry for (provider, key), returning nil if
+// nothing is cached.
+func Load(provider, key string) (*Entry, error) {
+	p, err := path(provider, key)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var e Entry
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// Save writes the entry for (provider, key), creating the provider's
+// cache directory if necessary.
+func Save(provider, key string, e *Entry) error {
+	p, err := path(provider, key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, 0o644)
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSaveAndLoadRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	want := &Entry{
+		Body:      []byte(`{"ok":true}`),
+		ETag:      `"abc"`,
+		ExpiresAt: time.Now().Add(time.Hour).Truncate(time.Second),
+		CachedAt:  time.Now().Truncate(time.Second),
+	}
+	if err := Save("tomorrowio", "uyo", want); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	got, err := Load("tomorrowio", "uyo")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if got == nil {
+		t.Fatal("expected a cached entry, got nil")
+	}
+	if string(got.Body) != string(want.Body) || got.ETag != want.ETag {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if !got.Fresh() {
+		t.Error("expected entry to be fresh")
+	}
+}
+
+func TestLoadMissingReturnsNil(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	got, err := Load("tomorrowio", "nowhere")
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if got != nil {
+		t.Errorf("expected nil for an uncached key, got %+v", got)
+	}
+}
+
+func TestEntryFreshExpired(t *testing.T) {
+	e := &Entry{ExpiresAt: time.Now().Add(-time.Minute)}
+	if e.Fresh() {
+		t.Error("expected an expired entry to not be fresh")
+	}
+}
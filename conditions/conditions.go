@@ -0,0 +1,53 @@
+// Package conditions normalizes provider-specific weather condition
+// codes into a shared enum and maps them to a display glyph, so the
+// rendered table doesn't need to know which provider produced a
+// forecast.
+package conditions
+
+// Code is a normalized weather condition, independent of any single
+// provider's code scheme.
+type Code string
+
+const (
+	Unknown      Code = ""
+	Clear        Code = "clear"
+	PartlyCloudy Code = "partly_cloudy"
+	Cloudy       Code = "cloudy"
+	Overcast     Code = "overcast"
+	Fog          Code = "fog"
+	Drizzle      Code = "drizzle"
+	Rain         Code = "rain"
+	Snow         Code = "snow"
+	Thunderstorm Code = "thunderstorm"
+)
+
+// Glyph returns a Unicode/emoji glyph for code. isDay picks between the
+// day and night variant for conditions that have one.
+func Glyph(code Code, isDay bool) string {
+	switch code {
+	case Clear:
+		if isDay {
+			return "☀"
+		}
+		return "🌙"
+	case PartlyCloudy:
+		if isDay {
+			return "⛅"
+		}
+		return "☁"
+	case Cloudy, Overcast:
+		return "☁"
+	case Fog:
+		return "🌫"
+	case Drizzle:
+		return "🌦"
+	case Rain:
+		return "🌧"
+	case Snow:
+		return "❄"
+	case Thunderstorm:
+		return "⛈"
+	default:
+		return "·"
+	}
+}
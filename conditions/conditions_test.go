@@ -0,0 +1,18 @@
+package conditions
+
+import "testing"
+
+func TestGlyphDayNightVariants(t *testing.T) {
+	if got := Glyph(Clear, true); got != "☀" {
+		t.Errorf("expected a sun glyph for clear/day, got %q", got)
+	}
+	if got := Glyph(Clear, false); got != "🌙" {
+		t.Errorf("expected a moon glyph for clear/night, got %q", got)
+	}
+}
+
+func TestGlyphUnknownFallsBack(t *testing.T) {
+	if got := Glyph(Unknown, true); got == "" {
+		t.Error("expected a non-empty fallback glyph for an unknown condition")
+	}
+}
@@ -0,0 +1,77 @@
+// Package config persists small user preferences (currently unit
+// choices) across runs.
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Units holds the user's preferred display units.
+type Units struct {
+	Temperature string `json:"temperature"` // "C", "F", or "K"
+	Wind        string `json:"wind"`        // "ms", "kmh", or "mph"
+}
+
+// TrackedCity is a saved dashboard entry: a display name paired with the
+// exact query string (place name or "lat,lon") passed to the provider.
+type TrackedCity struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+// Config is the on-disk user preferences file.
+type Config struct {
+	Units  Units         `json:"units"`
+	Cities []TrackedCity `json:"cities,omitempty"`
+}
+
+// Default returns the config used when none has been saved yet.
+func Default() Config {
+	return Config{Units: Units{Temperature: "C", Wind: "ms"}}
+}
+
+func path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "charm-go-weather", "config.json"), nil
+}
+
+// Load reads the saved config, falling back to Default if none exists.
+func Load() (Config, error) {
+	p, err := path()
+	if err != nil {
+		return Default(), err
+	}
+	data, err := os.ReadFile(p)
+	if os.IsNotExist(err) {
+		return Default(), nil
+	}
+	if err != nil {
+		return Default(), err
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return Default(), err
+	}
+	return cfg, nil
+}
+
+// Save writes cfg, creating the config directory if necessary.
+func Save(cfg Config) error {
+	p, err := path()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(p, data, 0o644)
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadReturnsDefaultWhenUnset(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Errorf("expected default config, got %+v", cfg)
+	}
+}
+
+func TestSaveAndLoadRoundTrip(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	want := Config{
+		Units:  Units{Temperature: "F", Wind: "mph"},
+		Cities: []TrackedCity{{Name: "Uyo", Query: "Uyo"}, {Name: "Lagos", Query: "6.52,3.38"}},
+	}
+	if err := Save(want); err != nil {
+		t.Fatalf("Save returned error: %v", err)
+	}
+
+	got, err := Load()
+	if err != nil {
+		t.Fatalf("Load returned error: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,53 @@
+// Package geocode resolves free-form place names to coordinates, since
+// several weather providers (met.no, OpenWeather's onecall-style
+// endpoints) require lat/lon rather than a city name.
+package geocode
+
+import "fmt"
+
+// Place is a single geocoding match.
+type Place struct {
+	Name    string
+	Admin1  string // state/region, if any
+	Country string
+	Lat     float64
+	Lon     float64
+}
+
+// Geocoder resolves a free-form query to candidate places.
+type Geocoder interface {
+	// Name returns the registry name of the geocoder.
+	Name() string
+	// Resolve returns the places matching query, most likely first. An
+	// empty slice (with a nil error) means no matches were found.
+	Resolve(query string) ([]Place, error)
+}
+
+// Factory builds a Geocoder configured with the given API key.
+type Factory func(apiKey string) Geocoder
+
+var registry = map[string]Factory{}
+
+// RegisterGeocoder makes a geocoder available under name via New.
+func RegisterGeocoder(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// New looks up the geocoder registered under name and constructs it with
+// the given API key.
+func New(name, apiKey string) (Geocoder, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown geocoder %q", name)
+	}
+	return factory(apiKey), nil
+}
+
+// Names returns the names of all registered geocoders.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,93 @@
+package geocode
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+func init() {
+	RegisterGeocoder("nominatim", func(apiKey string) Geocoder {
+		userAgent := apiKey
+		if userAgent == "" {
+			userAgent = "charm-go-weather/1.0 (https://github.com/uduakabaci/charm-go-weather)"
+		}
+		return &nominatim{userAgent: userAgent, baseURL: "https://nominatim.openstreetmap.org/search"}
+	})
+}
+
+// nominatim talks to OpenStreetMap's Nominatim search API. It is free
+// and keyless, but the usage policy requires an identifying
+// User-Agent on every request; we repurpose the apiKey slot for it so
+// the --api-key/<GEOCODER>_API_KEY plumbing stays uniform across
+// geocoders.
+type nominatim struct {
+	userAgent string
+	baseURL   string
+}
+
+func (g *nominatim) Name() string { return "nominatim" }
+
+type nominatimResult struct {
+	DisplayName string `json:"display_name"`
+	Lat         string `json:"lat"`
+	Lon         string `json:"lon"`
+	Address     struct {
+		State   string `json:"state"`
+		Country string `json:"country"`
+	} `json:"address"`
+}
+
+func (g *nominatim) Resolve(query string) ([]Place, error) {
+	reqURL := g.baseURL + "?q=" + url.QueryEscape(query) + "&format=json&addressdetails=1&limit=5"
+
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", g.userAgent)
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("nominatim: unexpected status %d: %s", res.StatusCode, body)
+	}
+
+	var raw []nominatimResult
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	places := make([]Place, 0, len(raw))
+	for _, r := range raw {
+		lat, err := strconv.ParseFloat(r.Lat, 64)
+		if err != nil {
+			continue
+		}
+		lon, err := strconv.ParseFloat(r.Lon, 64)
+		if err != nil {
+			continue
+		}
+		places = append(places, Place{
+			Name:    r.DisplayName,
+			Admin1:  r.Address.State,
+			Country: r.Address.Country,
+			Lat:     lat,
+			Lon:     lon,
+		})
+	}
+
+	return places, nil
+}
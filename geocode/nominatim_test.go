@@ -0,0 +1,36 @@
+package geocode
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNominatimResolve(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") == "" {
+			t.Error("expected a User-Agent header")
+		}
+		if r.URL.Query().Get("q") != "Paris" {
+			t.Errorf("expected q=Paris, got %q", r.URL.Query().Get("q"))
+		}
+		w.Write([]byte(`[
+			{"display_name": "Paris, Île-de-France, France", "lat": "48.8566", "lon": "2.3522", "address": {"state": "Île-de-France", "country": "France"}},
+			{"display_name": "Paris, Texas, United States", "lat": "33.6609", "lon": "-95.5555", "address": {"state": "Texas", "country": "United States"}}
+		]`))
+	}))
+	defer srv.Close()
+
+	g := &nominatim{userAgent: "charm-go-weather-test", baseURL: srv.URL}
+
+	places, err := g.Resolve("Paris")
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	if len(places) != 2 {
+		t.Fatalf("expected 2 places, got %d", len(places))
+	}
+	if places[0].Lat != 48.8566 || places[0].Lon != 2.3522 {
+		t.Errorf("unexpected coordinates for first match: %+v", places[0])
+	}
+}
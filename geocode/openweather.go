@@ -0,0 +1,71 @@
+package geocode
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+func init() {
+	RegisterGeocoder("openweather", func(apiKey string) Geocoder {
+		return &openWeather{apiKey: apiKey, baseURL: "https://api.openweathermap.org/geo/1.0/direct"}
+	})
+}
+
+// openWeather talks to OpenWeatherMap's geocoding API.
+type openWeather struct {
+	apiKey  string
+	baseURL string
+}
+
+func (g *openWeather) Name() string { return "openweather" }
+
+type openWeatherPlace struct {
+	Name    string  `json:"name"`
+	State   string  `json:"state"`
+	Country string  `json:"country"`
+	Lat     float64 `json:"lat"`
+	Lon     float64 `json:"lon"`
+}
+
+func (g *openWeather) Resolve(query string) ([]Place, error) {
+	if g.apiKey == "" {
+		return nil, fmt.Errorf("openweather: missing API key, set --api-key or OPENWEATHER_API_KEY")
+	}
+
+	reqURL := g.baseURL + "?q=" + url.QueryEscape(query) + "&limit=5&appid=" + url.QueryEscape(g.apiKey)
+	res, err := http.Get(reqURL)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("openweather: unexpected status %d: %s", res.StatusCode, body)
+	}
+
+	var raw []openWeatherPlace
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	places := make([]Place, 0, len(raw))
+	for _, r := range raw {
+		places = append(places, Place{
+			Name:    r.Name,
+			Admin1:  r.State,
+			Country: r.Country,
+			Lat:     r.Lat,
+			Lon:     r.Lon,
+		})
+	}
+
+	return places, nil
+}
@@ -0,0 +1,37 @@
+package geocode
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestOpenWeatherResolve(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("appid") != "test-key" {
+			t.Errorf("expected appid=test-key, got %q", r.URL.Query().Get("appid"))
+		}
+		w.Write([]byte(`[{"name": "Uyo", "state": "Akwa Ibom", "country": "NG", "lat": 5.0377, "lon": 7.9128}]`))
+	}))
+	defer srv.Close()
+
+	g := &openWeather{apiKey: "test-key", baseURL: srv.URL}
+
+	places, err := g.Resolve("Uyo")
+	if err != nil {
+		t.Fatalf("Resolve returned error: %v", err)
+	}
+	if len(places) != 1 {
+		t.Fatalf("expected 1 place, got %d", len(places))
+	}
+	if places[0].Country != "NG" {
+		t.Errorf("expected country NG, got %q", places[0].Country)
+	}
+}
+
+func TestOpenWeatherResolveMissingAPIKey(t *testing.T) {
+	g := &openWeather{}
+	if _, err := g.Resolve("Uyo"); err == nil {
+		t.Fatal("expected an error when the API key is missing")
+	}
+}
@@ -1,160 +1,521 @@
 package main
 
 import (
-	"encoding/json"
+	"flag"
 	"fmt"
-	"io"
-	"net/http"
-	"net/url"
 	"os"
+	"regexp"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/uduakabaci/charm-go-weather/conditions"
+	"github.com/uduakabaci/charm-go-weather/config"
+	"github.com/uduakabaci/charm-go-weather/geocode"
+	"github.com/uduakabaci/charm-go-weather/provider"
 )
 
 var baseStyle = lipgloss.NewStyle().
 	BorderStyle(lipgloss.NormalBorder()).
 	BorderForeground(lipgloss.Color("240"))
 
-type Weather struct {
-	Timelines struct {
-		Daily []struct {
-			Time   string `json:"time"`
-			Values struct {
-				TemperatureAvg float64 `json:"temperatureAvg"`
-				HumidityAvg    float64 `json:"humidityAvg"`
-			} `json:"values"`
-		} `json:"daily"`
-	} `json:"timelines"`
+var sidebarStyle = lipgloss.NewStyle().
+	BorderStyle(lipgloss.NormalBorder()).
+	BorderForeground(lipgloss.Color("240")).
+	Padding(0, 1)
+
+// coordPattern matches a typed "lat,lon" pair so it can bypass geocoding
+// and go straight to the provider.
+var coordPattern = regexp.MustCompile(`^\s*-?\d+(\.\d+)?\s*,\s*-?\d+(\.\d+)?\s*$`)
+
+// tabNames are the views cycled through with the tab key.
+var tabNames = []string{"Daily", "Hourly", "Now"}
+
+const (
+	tabDaily = iota
+	tabHourly
+	tabNow
+)
+
+var tempUnits = []string{"C", "F", "K"}
+var windUnits = []string{"ms", "kmh", "mph"}
+
+// CityForecastMsg announces that the city at index has finished (or
+// failed) fetching its forecast. The result itself lives on the city's
+// trackedCity so concurrent refreshes don't race on Model fields.
+type CityForecastMsg struct {
+	index int
+}
+
+// PlacesMsg carries the result of resolving a place name via the
+// Geocoder.
+type PlacesMsg struct {
+	places []geocode.Place
+	err    error
+}
+
+// placeItem adapts a geocode.Place to the bubbles/list.Item interface.
+type placeItem struct {
+	geocode.Place
+}
+
+func (p placeItem) Title() string { return p.Name }
+
+func (p placeItem) Description() string {
+	var location []string
+	if p.Admin1 != "" {
+		location = append(location, p.Admin1)
+	}
+	if p.Country != "" {
+		location = append(location, p.Country)
+	}
+	return fmt.Sprintf("%s (%.4f, %.4f)", strings.Join(location, ", "), p.Lat, p.Lon)
+}
+
+func (p placeItem) FilterValue() string { return p.Name }
+
+// trackedCity is a single entry on the dashboard: a saved location plus
+// the last forecast fetched for it. Its own mutex lets one slow request
+// refresh without blocking the others.
+type trackedCity struct {
+	mu       sync.Mutex
+	name     string // display name
+	query    string // exact string passed to provider.Fetch (place name or "lat,lon")
+	forecast provider.Forecast
+	err      error
+	updating bool
 }
 
-type WeatherMsg struct {
-	w   Weather
-	err error
+func (c *trackedCity) snapshot() (forecast provider.Forecast, err error, updating bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.forecast, c.err, c.updating
 }
 
-func (w *Weather) Decode(data []byte) error {
-	err := json.Unmarshal(data, &w)
+// cityItem adapts a trackedCity snapshot to the bubbles/list.Item
+// interface, showing a compact current-temperature-and-icon summary.
+type cityItem struct {
+	name    string
+	summary string
+}
+
+func (c cityItem) Title() string       { return c.name }
+func (c cityItem) Description() string { return c.summary }
+func (c cityItem) FilterValue() string { return c.name }
+
+// citySummary renders the compact sidebar line for a city: its current
+// condition glyph and temperature, or its error/loading state.
+func citySummary(c *trackedCity, units config.Units) string {
+	forecast, err, updating := c.snapshot()
+	if updating {
+		return "loading..."
+	}
 	if err != nil {
-		return err
+		return "error: " + err.Error()
+	}
+	if len(forecast.Hourly) == 0 {
+		return "no data yet"
 	}
-	return nil
+	now := forecast.Hourly[0]
+	temp, tempSymbol := convertTemp(now.Temperature, units.Temperature)
+	summary := fmt.Sprintf("%s %.1f%s", conditions.Glyph(now.Condition, now.IsDay), temp, tempSymbol)
+	if forecast.Stale {
+		summary += " (cached)"
+	}
+	return summary
 }
 
 type Model struct {
-	input        textinput.Model
-	w            Weather
-	table        table.Model
-	currentCity  string
-	updating     bool
-	gettingInput bool
-	mu           sync.Mutex
-	spinner      spinner.Model
+	input         textinput.Model
+	provider      provider.Provider
+	geocoder      geocode.Geocoder
+	cities        []*trackedCity
+	cityList      list.Model
+	table         table.Model
+	placeList     list.Model
+	units         config.Units
+	activeTab     int
+	gettingInput  bool
+	addingCity    bool
+	choosingPlace bool
+	spinner       spinner.Model
 }
 
 func (m *Model) Init() tea.Cmd {
 	s := spinner.New()
 	input := textinput.New()
-	input.Placeholder = "Enter city name"
+	input.Placeholder = "Enter city name or lat,lon"
 	input.CharLimit = 100
 	input.Width = 50
 	m.input = input
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("205"))
 	m.spinner = s
-	// Ask for city on startup
-	return func() tea.Msg {
-		return tea.KeyMsg{Type: tea.KeyCtrlI}
-	}
+
+	m.rebuildCityList()
+	m.InitTable()
+
+	return tea.Batch(m.refreshAll()...)
 }
 
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmd tea.Cmd
 	switch msg := msg.(type) {
-	case WeatherMsg:
+	case CityForecastMsg:
+		m.rebuildCityList()
+		if msg.index == m.cityList.Index() {
+			m.InitTable()
+		}
+		return m, nil
+
+	case PlacesMsg:
 		if msg.err != nil {
+			m.gettingInput = true
+			m.input.Focus()
+			return m, nil
+		}
+		switch len(msg.places) {
+		case 0:
+			m.gettingInput = true
+			m.input.Focus()
+			return m, nil
+		case 1:
+			return m, m.selectPlace(msg.places[0])
+		default:
+			items := make([]list.Item, len(msg.places))
+			for i, place := range msg.places {
+				items[i] = placeItem{place}
+			}
+			m.placeList = list.New(items, list.NewDefaultDelegate(), 50, 14)
+			m.placeList.Title = "Select a location"
+			m.choosingPlace = true
 			return m, nil
 		}
-		m.w = msg.w
-		m.InitTable()
-		return m, nil
 
 	case tea.KeyMsg:
 		switch msg.Type {
 		case tea.KeyCtrlC:
 			return m, tea.Quit
 		case tea.KeyCtrlR:
-			return m, m.LoadWeather(m.currentCity)
+			return m, tea.Batch(m.refreshAll()...)
 		case tea.KeyCtrlI:
+			m.addingCity = false
 			m.gettingInput = true
 			m.input.Focus()
 			return m, nil
+		case tea.KeyRunes:
+			if m.gettingInput || m.choosingPlace {
+				break
+			}
+			switch msg.String() {
+			case "v":
+				m.activeTab = (m.activeTab + 1) % len(tabNames)
+				m.InitTable()
+				return m, nil
+			case "u":
+				m.cycleUnits()
+				m.InitTable()
+				return m, nil
+			case "a":
+				m.addingCity = true
+				m.gettingInput = true
+				m.input.SetValue("")
+				m.input.Focus()
+				return m, nil
+			case "d":
+				m.removeSelectedCity()
+				return m, nil
+			case "J":
+				m.moveSelectedCity(1)
+				return m, nil
+			case "K":
+				m.moveSelectedCity(-1)
+				return m, nil
+			}
 		case tea.KeyEnter:
+			if m.choosingPlace {
+				selected, ok := m.placeList.SelectedItem().(placeItem)
+				m.choosingPlace = false
+				if !ok {
+					return m, nil
+				}
+				return m, m.selectPlace(selected.Place)
+			}
 			if m.gettingInput {
+				query := strings.TrimSpace(m.input.Value())
 				m.gettingInput = false
-				return m, m.LoadWeather(m.input.Value())
+				if coordPattern.MatchString(query) {
+					return m, m.addOrLoadCity(query, query)
+				}
+				return m, m.resolvePlace(query)
 			}
 		}
 	}
 
+	if m.choosingPlace {
+		m.placeList, cmd = m.placeList.Update(msg)
+		return m, cmd
+	}
+
 	if m.gettingInput {
 		m.input, cmd = m.input.Update(msg)
 		return m, cmd
 	}
 
+	if !m.choosingPlace && !m.gettingInput {
+		previous := m.cityList.Index()
+		m.cityList, cmd = m.cityList.Update(msg)
+		if m.cityList.Index() != previous {
+			m.InitTable()
+		}
+		return m, cmd
+	}
+
 	return m, cmd
 }
 
 func (m *Model) View() string {
-	view := ""
-	if m.updating {
-		view = fmt.Sprintf("\n\n   %s Loading weather data...", m.spinner.View())
+	if m.gettingInput {
+		prompt := "Enter a city to see weather for: "
+		if m.addingCity {
+			prompt = "Enter a city to add to the dashboard: "
+		}
+		return fmt.Sprintf("%s\n\n%s\n\n", prompt, m.input.View())
 	}
 
-	if m.gettingInput {
-		view = fmt.Sprintf("Enter a city to see weather infor: \n\n%s\n\n", m.input.View()) + "\n"
+	if m.choosingPlace {
+		return "\n" + m.placeList.View()
 	}
 
-	if !m.gettingInput && !m.updating {
-		view = "\nShowing weather data for " + m.currentCity + " \n" + baseStyle.Render(m.table.View())
+	sidebar := sidebarStyle.Render(m.cityList.View())
+
+	main := "\nNo cities tracked yet. Press a to add one.\n"
+	if len(m.cities) > 0 {
+		city := m.cities[m.cityList.Index()]
+		forecast, _, updating := city.snapshot()
+		header := "\nShowing weather data for " + city.name + " "
+		if forecast.Stale {
+			header += "(cached, offline) "
+		}
+		if updating {
+			header += fmt.Sprintf("%s refreshing... ", m.spinner.View())
+		}
+		header += fmt.Sprintf("[%s/%s]\n", m.units.Temperature, m.units.Wind)
+		main = header + renderTabs(m.activeTab) + "\n" + baseStyle.Render(m.table.View())
 	}
 
-	return fmt.Sprintf("%s\n\n\nPress ctrl+c to quit, ctrl+r to refresh, ctrl+i to change city", view)
+	view := lipgloss.JoinHorizontal(lipgloss.Top, sidebar, main)
+	return fmt.Sprintf("%s\n\n\nPress ctrl+c to quit, ctrl+r to refresh, a to add city, d to remove, J/K to reorder, v to switch view, u to cycle units", view)
 }
 
-func (m *Model) LoadWeather(city string) tea.Cmd {
-	return func() tea.Msg {
-		m.mu.Lock()
-		defer m.mu.Unlock()
+// renderTabs renders the Daily/Hourly/Now tab bar with active highlighted.
+func renderTabs(active int) string {
+	tabStyle := lipgloss.NewStyle().Padding(0, 1)
+	activeTabStyle := tabStyle.Bold(true).Underline(true)
 
-		if m.updating {
-			fmt.Println("Already fetching weather data")
-			return WeatherMsg{Weather{}, fmt.Errorf("Already fetching weather data")}
+	rendered := make([]string, len(tabNames))
+	for i, name := range tabNames {
+		if i == active {
+			rendered[i] = activeTabStyle.Render(name)
+			continue
 		}
+		rendered[i] = tabStyle.Render(name)
+	}
+	return strings.Join(rendered, "")
+}
 
-		m.updating = true
-		defer func() { m.updating = false }()
+// cycleUnits advances both the temperature and wind unit and persists the
+// choice so it survives across runs.
+func (m *Model) cycleUnits() {
+	m.units.Temperature = nextUnit(m.units.Temperature, tempUnits)
+	m.units.Wind = nextUnit(m.units.Wind, windUnits)
+	m.saveConfig()
+	m.rebuildCityList()
+}
 
-		body, err := FetchWeather(city)
-		if err != nil {
-			fmt.Println(err)
-			return WeatherMsg{err: err}
+func nextUnit(current string, options []string) string {
+	for i, opt := range options {
+		if opt == current {
+			return options[(i+1)%len(options)]
 		}
+	}
+	return options[0]
+}
 
-		weather := Weather{}
-		err = weather.Decode(body)
-		if err != nil {
-			fmt.Println(err)
-			return WeatherMsg{err: err}
+// convertTemp converts a Celsius reading to unit, returning the value and
+// its display symbol.
+func convertTemp(celsius float64, unit string) (float64, string) {
+	switch unit {
+	case "F":
+		return celsius*9/5 + 32, "°F"
+	case "K":
+		return celsius + 273.15, "K"
+	default:
+		return celsius, "°C"
+	}
+}
+
+// convertWind converts a meters-per-second reading to unit, returning the
+// value and its display symbol.
+func convertWind(ms float64, unit string) (float64, string) {
+	switch unit {
+	case "kmh":
+		return ms * 3.6, "km/h"
+	case "mph":
+		return ms * 2.23694, "mph"
+	default:
+		return ms, "m/s"
+	}
+}
+
+// formatClock renders t as a HH:MM clock, or "-" when the provider didn't
+// supply a value (e.g. met.no's compact endpoint has no sunrise/sunset).
+func formatClock(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	return t.Format("15:04")
+}
+
+// resolvePlace looks up query through the Geocoder.
+func (m *Model) resolvePlace(query string) tea.Cmd {
+	return func() tea.Msg {
+		places, err := m.geocoder.Resolve(query)
+		return PlacesMsg{places: places, err: err}
+	}
+}
+
+// selectPlace persists the chosen place's coordinates alongside its
+// display name and loads the weather for it.
+func (m *Model) selectPlace(p geocode.Place) tea.Cmd {
+	return m.addOrLoadCity(fmt.Sprintf("%g,%g", p.Lat, p.Lon), p.Name)
+}
+
+// addOrLoadCity either adds a new tracked city (when the "a" keybinding
+// started the input) or replaces the query for the currently selected
+// one (the ctrl+i "change city" flow), then kicks off a load for it.
+func (m *Model) addOrLoadCity(query, display string) tea.Cmd {
+	if m.addingCity || len(m.cities) == 0 {
+		m.cities = append(m.cities, &trackedCity{name: display, query: query})
+		m.saveConfig()
+		m.rebuildCityList()
+		m.cityList.Select(len(m.cities) - 1)
+		return m.loadCity(len(m.cities) - 1)
+	}
+
+	idx := m.cityList.Index()
+	city := m.cities[idx]
+	city.mu.Lock()
+	city.name = display
+	city.query = query
+	city.mu.Unlock()
+	m.saveConfig()
+	m.rebuildCityList()
+	return m.loadCity(idx)
+}
+
+// removeSelectedCity drops the currently selected city from the
+// dashboard.
+func (m *Model) removeSelectedCity() {
+	if len(m.cities) == 0 {
+		return
+	}
+	idx := m.cityList.Index()
+	m.cities = append(m.cities[:idx], m.cities[idx+1:]...)
+	m.saveConfig()
+	m.rebuildCityList()
+	m.InitTable()
+}
+
+// moveSelectedCity reorders the currently selected city by delta
+// positions (-1 moves it up, +1 moves it down) and keeps it selected.
+func (m *Model) moveSelectedCity(delta int) {
+	idx := m.cityList.Index()
+	target := idx + delta
+	if target < 0 || target >= len(m.cities) {
+		return
+	}
+	m.cities[idx], m.cities[target] = m.cities[target], m.cities[idx]
+	m.saveConfig()
+	m.rebuildCityList()
+	m.cityList.Select(target)
+}
+
+// rebuildCityList refreshes the sidebar list's items from the current
+// city states, preserving the selected index.
+func (m *Model) rebuildCityList() {
+	selected := 0
+	if m.cityList.Items() != nil {
+		selected = m.cityList.Index()
+	}
+
+	items := make([]list.Item, len(m.cities))
+	for i, city := range m.cities {
+		items[i] = cityItem{name: city.name, summary: citySummary(city, m.units)}
+	}
+
+	m.cityList = list.New(items, list.NewDefaultDelegate(), 28, 14)
+	m.cityList.Title = "Cities"
+	m.cityList.SetShowHelp(false)
+	if selected < len(items) {
+		m.cityList.Select(selected)
+	}
+}
+
+// refreshAll returns one load command per tracked city so ctrl+r refreshes
+// the whole dashboard concurrently via tea.Batch.
+func (m *Model) refreshAll() []tea.Cmd {
+	cmds := make([]tea.Cmd, len(m.cities))
+	for i := range m.cities {
+		cmds[i] = m.loadCity(i)
+	}
+	return cmds
+}
+
+// loadCity fetches the forecast for the city at index. Each city has its
+// own mutex, so a slow request for one city never blocks the others.
+func (m *Model) loadCity(index int) tea.Cmd {
+	city := m.cities[index]
+	return func() tea.Msg {
+		city.mu.Lock()
+		if city.updating {
+			city.mu.Unlock()
+			return nil
+		}
+		city.updating = true
+		query := city.query
+		city.mu.Unlock()
+
+		forecast, err := m.provider.Fetch(query)
+
+		city.mu.Lock()
+		city.updating = false
+		city.err = err
+		if err == nil {
+			city.forecast = forecast
 		}
+		city.mu.Unlock()
 
-		m.w = weather
-		m.currentCity = city
-		return WeatherMsg{w: weather}
+		return CityForecastMsg{index: index}
+	}
+}
+
+// saveConfig persists the current units and tracked cities together, since
+// they share a single on-disk config file.
+func (m *Model) saveConfig() {
+	cities := make([]config.TrackedCity, len(m.cities))
+	for i, city := range m.cities {
+		cities[i] = config.TrackedCity{Name: city.name, Query: city.query}
+	}
+	if err := config.Save(config.Config{Units: m.units, Cities: cities}); err != nil {
+		fmt.Println("failed to save config:", err)
 	}
 }
 
@@ -162,17 +523,59 @@ func (m *Model) InitTable() {
 	m.table = table.New()
 	rows := []table.Row{}
 	columns := []table.Column{
-		{Title: "Time", Width: 10},
+		{Title: "", Width: 3},
+		{Title: "Time", Width: 16},
 		{Title: "Temperature", Width: 15},
 		{Title: "Humidity", Width: 10},
+		{Title: "Wind", Width: 16},
+	}
+
+	var forecast provider.Forecast
+	if len(m.cities) > 0 {
+		forecast, _, _ = m.cities[m.cityList.Index()].snapshot()
+	}
+
+	timeFormat := "2006-01-02"
+	points := forecast.Daily
+	switch m.activeTab {
+	case tabHourly:
+		timeFormat = "2006-01-02 15:04"
+		points = forecast.Hourly
+	case tabNow:
+		timeFormat = "2006-01-02 15:04"
+		points = forecast.Hourly
+		if len(points) > 1 {
+			points = points[:1]
+		}
+		columns = append(columns,
+			table.Column{Title: "Pressure", Width: 10},
+			table.Column{Title: "Clouds", Width: 8},
+			table.Column{Title: "Precip", Width: 8},
+			table.Column{Title: "Sunrise", Width: 8},
+			table.Column{Title: "Sunset", Width: 8},
+		)
 	}
 
-	for _, day := range m.w.Timelines.Daily {
-		rows = append(rows, []string{
-			day.Time[:10],
-			fmt.Sprintf("%.2f°C", day.Values.TemperatureAvg),
-			fmt.Sprintf("%.2f%%", day.Values.HumidityAvg),
-		})
+	for _, point := range points {
+		temp, tempSymbol := convertTemp(point.Temperature, m.units.Temperature)
+		wind, windSymbol := convertWind(point.WindSpeed, m.units.Wind)
+		row := table.Row{
+			conditions.Glyph(point.Condition, point.IsDay),
+			point.Time.Format(timeFormat),
+			fmt.Sprintf("%.2f%s", temp, tempSymbol),
+			fmt.Sprintf("%.2f%%", point.Humidity),
+			fmt.Sprintf("%.2f %s @ %.0f°", wind, windSymbol, point.WindDirection),
+		}
+		if m.activeTab == tabNow {
+			row = append(row,
+				fmt.Sprintf("%.0f hPa", point.Pressure),
+				fmt.Sprintf("%.0f%%", point.CloudCover),
+				fmt.Sprintf("%.1f mm", point.Precipitation),
+				formatClock(point.Sunrise),
+				formatClock(point.Sunset),
+			)
+		}
+		rows = append(rows, row)
 	}
 	t := table.New(
 		table.WithColumns(columns),
@@ -191,9 +594,46 @@ func (m *Model) InitTable() {
 }
 
 func main() {
-	m := Model{}
-	go m.LoadWeather("uyo")
-	m.InitTable()
+	providerName := flag.String("provider", envOrDefault("WEATHER_PROVIDER", "tomorrowio"), "weather provider to use ("+strings.Join(provider.Names(), ", ")+")")
+	apiKey := flag.String("api-key", "", "API key for the selected provider (falls back to <PROVIDER>_API_KEY env var)")
+	geocoderName := flag.String("geocoder", envOrDefault("WEATHER_GEOCODER", "nominatim"), "geocoder to use for place name lookups ("+strings.Join(geocode.Names(), ", ")+")")
+	geocoderAPIKey := flag.String("geocoder-api-key", "", "API key for the selected geocoder (falls back to <GEOCODER>_API_KEY env var)")
+	flag.Parse()
+
+	if *apiKey == "" {
+		*apiKey = os.Getenv(strings.ToUpper(*providerName) + "_API_KEY")
+	}
+	if *geocoderAPIKey == "" {
+		*geocoderAPIKey = os.Getenv(strings.ToUpper(*geocoderName) + "_API_KEY")
+	}
+
+	p, err := provider.New(*providerName, *apiKey)
+	if err != nil {
+		fmt.Println("fatal:", err)
+		os.Exit(1)
+	}
+
+	g, err := geocode.New(*geocoderName, *geocoderAPIKey)
+	if err != nil {
+		fmt.Println("fatal:", err)
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Println("failed to load config, using defaults:", err)
+		cfg = config.Default()
+	}
+
+	cities := make([]*trackedCity, 0, len(cfg.Cities))
+	for _, c := range cfg.Cities {
+		cities = append(cities, &trackedCity{name: c.Name, query: c.Query})
+	}
+	if len(cities) == 0 {
+		cities = append(cities, &trackedCity{name: "uyo", query: "uyo"})
+	}
+
+	m := Model{provider: p, geocoder: g, units: cfg.Units, cities: cities}
 	if len(os.Getenv("DEBUG")) > 0 {
 		f, err := tea.LogToFile("debug.log", "debug")
 		if err != nil {
@@ -208,17 +648,9 @@ func main() {
 	}
 }
 
-func FetchWeather(city string) ([]byte, error) {
-	res, err := http.Get("https://api.tomorrow.io/v4/weather/forecast?location=" + url.QueryEscape(city) + "&apikey=BmOCo3WGWx0GwXXnfwcXcqPuyPN4VLor")
-	if err != nil {
-		return []byte{}, err
-	}
-	body, err := io.ReadAll(res.Body)
-	res.Body.Close()
-
-	if err != nil {
-		return []byte{}, err
+func envOrDefault(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
 	}
-
-	return body, nil
+	return fallback
 }
@@ -0,0 +1,154 @@
+package provider
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uduakabaci/charm-go-weather/cache"
+)
+
+// fetchResult is the outcome of a cached HTTP fetch.
+type fetchResult struct {
+	body  []byte
+	stale bool // true if body came from cache because the live request failed
+}
+
+// fetchWithCache performs a GET against reqURL, honoring and refreshing a
+// disk cache entry keyed by (providerName, key). If the cached entry is
+// still fresh (per Expires/Cache-Control: max-age), it is returned
+// without a network call. Otherwise a conditional request is made with
+// If-None-Match/If-Modified-Since, and a 304 response reuses the cached
+// body. If the network request fails outright, a stale cache entry is
+// returned instead of an error so the app stays usable offline.
+func fetchWithCache(providerName, key, reqURL string, setHeaders func(*http.Request)) (fetchResult, error) {
+	entry, _ := cache.Load(providerName, key)
+	if entry.Fresh() {
+		return fetchResult{body: entry.Body}, nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return fetchResult{}, err
+	}
+	if setHeaders != nil {
+		setHeaders(req)
+	}
+	if entry != nil {
+		if entry.ETag != "" {
+			req.Header.Set("If-None-Match", entry.ETag)
+		}
+		if entry.LastModified != "" {
+			req.Header.Set("If-Modified-Since", entry.LastModified)
+		}
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		if entry != nil {
+			return fetchResult{body: entry.Body, stale: true}, nil
+		}
+		return fetchResult{}, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode == http.StatusNotModified {
+		if entry == nil {
+			return fetchResult{}, fmt.Errorf("%s: got 304 with no cached response", providerName)
+		}
+		refreshed := *entry
+		refreshed.ExpiresAt = expiresAt(res.Header)
+		refreshed.CachedAt = now()
+		_ = cache.Save(providerName, key, &refreshed)
+		return fetchResult{body: entry.Body}, nil
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		if entry != nil {
+			return fetchResult{body: entry.Body, stale: true}, nil
+		}
+		return fetchResult{}, err
+	}
+
+	if res.StatusCode != http.StatusOK {
+		if entry != nil {
+			return fetchResult{body: entry.Body, stale: true}, nil
+		}
+		return fetchResult{}, fmt.Errorf("%s: unexpected status %d: %s", providerName, res.StatusCode, body)
+	}
+
+	_ = cache.Save(providerName, key, &cache.Entry{
+		Body:         body,
+		ETag:         res.Header.Get("ETag"),
+		LastModified: res.Header.Get("Last-Modified"),
+		ExpiresAt:    expiresAt(res.Header),
+		CachedAt:     now(),
+	})
+
+	return fetchResult{body: body}, nil
+}
+
+// expiresAt derives the freshness deadline from Cache-Control: max-age
+// (preferred) or Expires.
+func expiresAt(h http.Header) time.Time {
+	if cc := h.Get("Cache-Control"); cc != "" {
+		for _, part := range strings.Split(cc, ",") {
+			part = strings.TrimSpace(part)
+			if secs, ok := strings.CutPrefix(part, "max-age="); ok {
+				if n, err := strconv.Atoi(secs); err == nil {
+					return now().Add(time.Duration(n) * time.Second)
+				}
+			}
+		}
+	}
+	if exp := h.Get("Expires"); exp != "" {
+		if t, err := http.ParseTime(exp); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// normalizeKey turns free-form location input into a stable cache key.
+func normalizeKey(city string) string {
+	return strings.ToLower(strings.TrimSpace(city))
+}
+
+// parseLatLon extracts coordinates from a "lat,lon" location string.
+func parseLatLon(city string) (lat, lon string, err error) {
+	parts := strings.SplitN(city, ",", 2)
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("expected \"lat,lon\", got %q", city)
+	}
+	lat, lon = strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1])
+	if _, err := strconv.ParseFloat(lat, 64); err != nil {
+		return "", "", fmt.Errorf("invalid latitude %q", lat)
+	}
+	if _, err := strconv.ParseFloat(lon, 64); err != nil {
+		return "", "", fmt.Errorf("invalid longitude %q", lon)
+	}
+	return lat, lon, nil
+}
+
+var now = time.Now
+
+// dailyFromHourly collapses hourly data points down to one per calendar
+// day (the first entry seen for that day), for providers that only
+// expose an hourly/3-hourly timeline.
+func dailyFromHourly(hourly []DataPoint) []DataPoint {
+	var daily []DataPoint
+	seen := map[string]bool{}
+	for _, dp := range hourly {
+		day := dp.Time.Format("2006-01-02")
+		if seen[day] {
+			continue
+		}
+		seen[day] = true
+		daily = append(daily, dp)
+	}
+	return daily
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDailyFromHourlyCollapsesToOnePerDay(t *testing.T) {
+	hourly := []DataPoint{
+		{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Temperature: 10},
+		{Time: time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC), Temperature: 12},
+		{Time: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Temperature: 9},
+	}
+
+	daily := dailyFromHourly(hourly)
+	if len(daily) != 2 {
+		t.Fatalf("expected 2 daily entries, got %d", len(daily))
+	}
+	if daily[0].Temperature != 10 {
+		t.Errorf("expected the first entry of the day to win, got %v", daily[0].Temperature)
+	}
+}
+
+func TestFetchWithCacheServesFreshEntryWithoutNetwork(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	var hits int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.Header().Set("Cache-Control", "max-age=3600")
+		w.Write([]byte("first"))
+	}))
+	defer srv.Close()
+
+	first, err := fetchWithCache("test", "city", srv.URL, nil)
+	if err != nil {
+		t.Fatalf("first fetch returned error: %v", err)
+	}
+	if string(first.body) != "first" {
+		t.Fatalf("expected body %q, got %q", "first", first.body)
+	}
+
+	second, err := fetchWithCache("test", "city", srv.URL, nil)
+	if err != nil {
+		t.Fatalf("second fetch returned error: %v", err)
+	}
+	if second.stale {
+		t.Error("expected a fresh cache hit, not stale")
+	}
+	if hits != 1 {
+		t.Errorf("expected 1 network request, server saw %d", hits)
+	}
+}
+
+func TestFetchWithCacheRevalidatesOn304(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte("payload"))
+	}))
+	defer srv.Close()
+
+	if _, err := fetchWithCache("test", "city", srv.URL, nil); err != nil {
+		t.Fatalf("first fetch returned error: %v", err)
+	}
+
+	result, err := fetchWithCache("test", "city", srv.URL, nil)
+	if err != nil {
+		t.Fatalf("revalidating fetch returned error: %v", err)
+	}
+	if string(result.body) != "payload" {
+		t.Errorf("expected cached body %q after a 304, got %q", "payload", result.body)
+	}
+	if result.stale {
+		t.Error("a successful revalidation should not be marked stale")
+	}
+}
+
+func TestFetchWithCacheFallsBackToStaleOnNetworkFailure(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("cached payload"))
+	}))
+
+	if _, err := fetchWithCache("test", "city", srv.URL, nil); err != nil {
+		t.Fatalf("first fetch returned error: %v", err)
+	}
+	url := srv.URL
+	srv.Close() // subsequent requests will now fail to connect
+
+	result, err := fetchWithCache("test", "city", url, nil)
+	if err != nil {
+		t.Fatalf("expected a stale cache fallback, got error: %v", err)
+	}
+	if !result.stale {
+		t.Error("expected the result to be marked stale")
+	}
+	if string(result.body) != "cached payload" {
+		t.Errorf("expected stale body %q, got %q", "cached payload", result.body)
+	}
+}
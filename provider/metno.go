@@ -0,0 +1,151 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/uduakabaci/charm-go-weather/conditions"
+)
+
+func init() {
+	RegisterProvider("metno", func(apiKey string) Provider {
+		// met.no's locationforecast API is free and keyless, but requires
+		// an identifying User-Agent on every request; we repurpose the
+		// apiKey slot for it so the --api-key/<PROVIDER>_API_KEY plumbing
+		// stays uniform across providers.
+		userAgent := apiKey
+		if userAgent == "" {
+			userAgent = "charm-go-weather/1.0 (https://github.com/uduakabaci/charm-go-weather)"
+		}
+		return &metNo{userAgent: userAgent, baseURL: "https://api.met.no/weatherapi/locationforecast/2.0/compact"}
+	})
+}
+
+// metNo talks to met.no/Yr's locationforecast 2.0 endpoint. It requires
+// coordinates rather than a place name.
+type metNo struct {
+	userAgent string
+	baseURL   string
+}
+
+func (p *metNo) Name() string { return "metno" }
+
+type metNoSummary struct {
+	SymbolCode string `json:"symbol_code"`
+}
+
+type metNoResponse struct {
+	Properties struct {
+		Timeseries []struct {
+			Time string `json:"time"`
+			Data struct {
+				Instant struct {
+					Details struct {
+						AirTemperature        float64 `json:"air_temperature"`
+						RelativeHumidity      float64 `json:"relative_humidity"`
+						WindSpeed             float64 `json:"wind_speed"`
+						WindFromDirection     float64 `json:"wind_from_direction"`
+						AirPressureAtSeaLevel float64 `json:"air_pressure_at_sea_level"`
+						CloudAreaFraction     float64 `json:"cloud_area_fraction"`
+					} `json:"details"`
+				} `json:"instant"`
+				Next1Hours struct {
+					Summary metNoSummary `json:"summary"`
+					Details struct {
+						PrecipitationAmount float64 `json:"precipitation_amount"`
+					} `json:"details"`
+				} `json:"next_1_hours"`
+				Next6Hours struct {
+					Summary metNoSummary `json:"summary"`
+				} `json:"next_6_hours"`
+				Next12Hours struct {
+					Summary metNoSummary `json:"summary"`
+				} `json:"next_12_hours"`
+			} `json:"data"`
+		} `json:"timeseries"`
+	} `json:"properties"`
+}
+
+func (p *metNo) Fetch(city string) (Forecast, error) {
+	lat, lon, err := parseLatLon(city)
+	if err != nil {
+		return Forecast{}, fmt.Errorf("metno: %w (met.no requires a \"lat,lon\" location)", err)
+	}
+
+	reqURL := fmt.Sprintf("%s?lat=%s&lon=%s", p.baseURL, lat, lon)
+	result, err := fetchWithCache(p.Name(), lat+","+lon, reqURL, func(req *http.Request) {
+		req.Header.Set("User-Agent", p.userAgent)
+	})
+	if err != nil {
+		return Forecast{}, err
+	}
+
+	var raw metNoResponse
+	if err := json.Unmarshal(result.body, &raw); err != nil {
+		return Forecast{}, err
+	}
+
+	forecast := Forecast{}
+	for _, entry := range raw.Properties.Timeseries {
+		t, err := time.Parse(time.RFC3339, entry.Time)
+		if err != nil {
+			t = time.Time{}
+		}
+		symbol := entry.Data.Next1Hours.Summary.SymbolCode
+		if symbol == "" {
+			symbol = entry.Data.Next6Hours.Summary.SymbolCode
+		}
+		if symbol == "" {
+			symbol = entry.Data.Next12Hours.Summary.SymbolCode
+		}
+		forecast.Hourly = append(forecast.Hourly, DataPoint{
+			Time:          t,
+			Temperature:   entry.Data.Instant.Details.AirTemperature,
+			Humidity:      entry.Data.Instant.Details.RelativeHumidity,
+			WindSpeed:     entry.Data.Instant.Details.WindSpeed,
+			WindDirection: entry.Data.Instant.Details.WindFromDirection,
+			Pressure:      entry.Data.Instant.Details.AirPressureAtSeaLevel,
+			CloudCover:    entry.Data.Instant.Details.CloudAreaFraction,
+			Precipitation: entry.Data.Next1Hours.Details.PrecipitationAmount,
+			Icon:          symbol,
+			Condition:     metNoCondition(symbol),
+			IsDay:         !strings.HasSuffix(symbol, "_night"),
+		})
+	}
+	// locationforecast only returns an hourly timeseries; collapse it
+	// down to one entry per day for the daily view.
+	forecast.Daily = dailyFromHourly(forecast.Hourly)
+	forecast.Stale = result.stale
+
+	return forecast, nil
+}
+
+// metNoCondition maps a met.no symbol_code (e.g. "partlycloudy_day") to a
+// normalized condition, ignoring the trailing _day/_night/_polartwilight
+// variant.
+func metNoCondition(symbol string) conditions.Code {
+	base, _, _ := strings.Cut(symbol, "_")
+	switch {
+	case base == "clearsky" || base == "fair":
+		return conditions.Clear
+	case base == "partlycloudy":
+		return conditions.PartlyCloudy
+	case base == "cloudy":
+		return conditions.Overcast
+	case base == "fog":
+		return conditions.Fog
+	case strings.Contains(base, "thunder"):
+		return conditions.Thunderstorm
+	case strings.Contains(base, "snow") || strings.Contains(base, "sleet"):
+		return conditions.Snow
+	case strings.Contains(base, "rainshowers") || base == "lightrain" || base == "lightrainshowers":
+		return conditions.Drizzle
+	case strings.Contains(base, "rain"):
+		return conditions.Rain
+	default:
+		return conditions.Unknown
+	}
+}
@@ -0,0 +1,94 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/uduakabaci/charm-go-weather/conditions"
+)
+
+func TestMetNoFetch(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") == "" {
+			t.Error("expected a User-Agent header")
+		}
+		if r.URL.Query().Get("lat") != "5.03" || r.URL.Query().Get("lon") != "7.91" {
+			t.Errorf("expected lat=5.03&lon=7.91, got %s", r.URL.RawQuery)
+		}
+		w.Write([]byte(`{
+			"properties": {
+				"timeseries": [
+					{"time": "2024-01-01T00:00:00Z", "data": {
+						"instant": {"details": {"air_temperature": 19.5, "relative_humidity": 70, "wind_speed": 4.1, "wind_from_direction": 210, "air_pressure_at_sea_level": 1012.3, "cloud_area_fraction": 45}},
+						"next_1_hours": {"summary": {"symbol_code": "partlycloudy_day"}, "details": {"precipitation_amount": 0.2}}
+					}}
+				]
+			}
+		}`))
+	}))
+	defer srv.Close()
+
+	p := &metNo{userAgent: "charm-go-weather-test", baseURL: srv.URL}
+
+	forecast, err := p.Fetch("5.03,7.91")
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+	if len(forecast.Daily) != 1 {
+		t.Fatalf("expected 1 daily entry, got %d", len(forecast.Daily))
+	}
+	if forecast.Daily[0].Icon != "partlycloudy_day" {
+		t.Errorf("expected icon partlycloudy_day, got %q", forecast.Daily[0].Icon)
+	}
+	if forecast.Daily[0].Condition != conditions.PartlyCloudy {
+		t.Errorf("expected condition PartlyCloudy, got %q", forecast.Daily[0].Condition)
+	}
+	if !forecast.Daily[0].IsDay {
+		t.Error("expected IsDay to be true for a _day symbol")
+	}
+	if forecast.Daily[0].WindDirection != 210 {
+		t.Errorf("expected wind direction 210, got %v", forecast.Daily[0].WindDirection)
+	}
+	if forecast.Daily[0].Pressure != 1012.3 {
+		t.Errorf("expected pressure 1012.3, got %v", forecast.Daily[0].Pressure)
+	}
+	if forecast.Daily[0].CloudCover != 45 {
+		t.Errorf("expected cloud cover 45, got %v", forecast.Daily[0].CloudCover)
+	}
+	if forecast.Daily[0].Precipitation != 0.2 {
+		t.Errorf("expected precipitation 0.2, got %v", forecast.Daily[0].Precipitation)
+	}
+	if len(forecast.Hourly) != 1 {
+		t.Fatalf("expected 1 hourly entry, got %d", len(forecast.Hourly))
+	}
+}
+
+func TestMetNoCondition(t *testing.T) {
+	cases := map[string]conditions.Code{
+		"clearsky_day":      conditions.Clear,
+		"fair_night":        conditions.Clear,
+		"partlycloudy_day":  conditions.PartlyCloudy,
+		"cloudy":            conditions.Overcast,
+		"fog":               conditions.Fog,
+		"lightrain":         conditions.Drizzle,
+		"rain":              conditions.Rain,
+		"heavysnow":         conditions.Snow,
+		"rainandthunder":    conditions.Thunderstorm,
+		"something_strange": conditions.Unknown,
+	}
+	for symbol, want := range cases {
+		if got := metNoCondition(symbol); got != want {
+			t.Errorf("metNoCondition(%q) = %q, want %q", symbol, got, want)
+		}
+	}
+}
+
+func TestMetNoFetchRejectsPlaceNames(t *testing.T) {
+	p := &metNo{userAgent: "charm-go-weather-test"}
+	if _, err := p.Fetch("Uyo"); err == nil {
+		t.Fatal("expected an error for a non-coordinate location")
+	}
+}
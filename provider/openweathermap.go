@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/uduakabaci/charm-go-weather/conditions"
+)
+
+func init() {
+	RegisterProvider("openweathermap", func(apiKey string) Provider {
+		return &openWeatherMap{apiKey: apiKey, baseURL: "https://api.openweathermap.org/data/2.5/forecast"}
+	})
+}
+
+// openWeatherMap talks to the OpenWeatherMap 5 day / 3 hour forecast
+// endpoint.
+type openWeatherMap struct {
+	apiKey  string
+	baseURL string
+}
+
+func (p *openWeatherMap) Name() string { return "openweathermap" }
+
+type openWeatherMapResponse struct {
+	List []struct {
+		Dt   int64 `json:"dt"`
+		Main struct {
+			Temp     float64 `json:"temp"`
+			Humidity float64 `json:"humidity"`
+			Pressure float64 `json:"pressure"`
+		} `json:"main"`
+		Wind struct {
+			Speed float64 `json:"speed"`
+			Deg   float64 `json:"deg"`
+		} `json:"wind"`
+		Clouds struct {
+			All float64 `json:"all"`
+		} `json:"clouds"`
+		Pop     float64 `json:"pop"`
+		Weather []struct {
+			Main string `json:"main"`
+			Icon string `json:"icon"`
+		} `json:"weather"`
+		Sys struct {
+			Pod string `json:"pod"` // "d" or "n"
+		} `json:"sys"`
+	} `json:"list"`
+	City struct {
+		Sunrise int64 `json:"sunrise"`
+		Sunset  int64 `json:"sunset"`
+	} `json:"city"`
+}
+
+func (p *openWeatherMap) Fetch(city string) (Forecast, error) {
+	if p.apiKey == "" {
+		return Forecast{}, fmt.Errorf("openweathermap: missing API key, set --api-key or OPENWEATHERMAP_API_KEY")
+	}
+
+	reqURL := p.baseURL + "?" + p.locationQuery(city) + "&units=metric&appid=" + url.QueryEscape(p.apiKey)
+	result, err := fetchWithCache(p.Name(), normalizeKey(city), reqURL, nil)
+	if err != nil {
+		return Forecast{}, err
+	}
+
+	var raw openWeatherMapResponse
+	if err := json.Unmarshal(result.body, &raw); err != nil {
+		return Forecast{}, err
+	}
+
+	sunrise := time.Unix(raw.City.Sunrise, 0).UTC()
+	sunset := time.Unix(raw.City.Sunset, 0).UTC()
+
+	forecast := Forecast{}
+	for _, entry := range raw.List {
+		dp := DataPoint{
+			Time:          time.Unix(entry.Dt, 0).UTC(),
+			Temperature:   entry.Main.Temp,
+			Humidity:      entry.Main.Humidity,
+			Pressure:      entry.Main.Pressure,
+			WindSpeed:     entry.Wind.Speed,
+			WindDirection: entry.Wind.Deg,
+			CloudCover:    entry.Clouds.All,
+			Precipitation: entry.Pop * 100,
+			Sunrise:       sunrise,
+			Sunset:        sunset,
+			IsDay:         entry.Sys.Pod != "n",
+		}
+		if len(entry.Weather) > 0 {
+			dp.Conditions = entry.Weather[0].Main
+			dp.Icon = entry.Weather[0].Icon
+			dp.Condition = openWeatherCondition(entry.Weather[0].Icon)
+		}
+		forecast.Hourly = append(forecast.Hourly, dp)
+	}
+	// The forecast endpoint only returns 3-hourly data points; collapse
+	// them down to one per day for the daily view.
+	forecast.Daily = dailyFromHourly(forecast.Hourly)
+	forecast.Stale = result.stale
+
+	return forecast, nil
+}
+
+// locationQuery builds the location portion of the request: lat/lon when
+// city is a "lat,lon" pair (required by OpenWeather's onecall-style
+// endpoints), otherwise a plain place name.
+func (p *openWeatherMap) locationQuery(city string) string {
+	if lat, lon, err := parseLatLon(city); err == nil {
+		return "lat=" + url.QueryEscape(lat) + "&lon=" + url.QueryEscape(lon)
+	}
+	return "q=" + url.QueryEscape(city)
+}
+
+// openWeatherCondition maps an OpenWeatherMap icon code (e.g. "10d") to a
+// normalized condition, ignoring the trailing day/night suffix.
+func openWeatherCondition(icon string) conditions.Code {
+	switch strings.TrimSuffix(strings.TrimSuffix(icon, "d"), "n") {
+	case "01":
+		return conditions.Clear
+	case "02", "03":
+		return conditions.PartlyCloudy
+	case "04":
+		return conditions.Overcast
+	case "09":
+		return conditions.Drizzle
+	case "10":
+		return conditions.Rain
+	case "11":
+		return conditions.Thunderstorm
+	case "13":
+		return conditions.Snow
+	case "50":
+		return conditions.Fog
+	default:
+		return conditions.Unknown
+	}
+}
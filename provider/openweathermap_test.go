@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/uduakabaci/charm-go-weather/conditions"
+)
+
+func TestOpenWeatherMapFetch(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") != "Uyo" {
+			t.Errorf("expected q=Uyo, got %q", r.URL.Query().Get("q"))
+		}
+		w.Write([]byte(`{
+			"list": [
+				{"dt": 1704067200, "main": {"temp": 24.1, "humidity": 55, "pressure": 1015}, "wind": {"speed": 2.1, "deg": 90}, "clouds": {"all": 30}, "pop": 0.2, "weather": [{"main": "Clear", "icon": "01d"}], "sys": {"pod": "d"}}
+			],
+			"city": {"sunrise": 1704088800, "sunset": 1704131400}
+		}`))
+	}))
+	defer srv.Close()
+
+	p := &openWeatherMap{apiKey: "test-key", baseURL: srv.URL}
+
+	forecast, err := p.Fetch("Uyo")
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+	if len(forecast.Daily) != 1 {
+		t.Fatalf("expected 1 daily entry, got %d", len(forecast.Daily))
+	}
+	if forecast.Daily[0].Conditions != "Clear" {
+		t.Errorf("expected conditions Clear, got %q", forecast.Daily[0].Conditions)
+	}
+	if forecast.Daily[0].Icon != "01d" {
+		t.Errorf("expected icon 01d, got %q", forecast.Daily[0].Icon)
+	}
+	if forecast.Daily[0].Condition != conditions.Clear {
+		t.Errorf("expected condition Clear, got %q", forecast.Daily[0].Condition)
+	}
+	if !forecast.Daily[0].IsDay {
+		t.Error("expected IsDay to be true for pod=d")
+	}
+	if forecast.Daily[0].WindDirection != 90 {
+		t.Errorf("expected wind direction 90, got %v", forecast.Daily[0].WindDirection)
+	}
+	if forecast.Daily[0].Sunrise.IsZero() || forecast.Daily[0].Sunset.IsZero() {
+		t.Error("expected non-zero sunrise/sunset")
+	}
+	if len(forecast.Hourly) != 1 {
+		t.Fatalf("expected 1 hourly entry, got %d", len(forecast.Hourly))
+	}
+}
@@ -0,0 +1,81 @@
+// Package provider defines the WeatherProvider abstraction used by the
+// Bubble Tea model to fetch forecasts without depending on any single
+// upstream API's JSON shape.
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/uduakabaci/charm-go-weather/conditions"
+)
+
+// DataPoint is a single normalized forecast entry, independent of which
+// upstream API produced it.
+type DataPoint struct {
+	Time          time.Time
+	Temperature   float64 // degrees Celsius
+	Humidity      float64 // percent
+	WindSpeed     float64 // meters per second
+	WindDirection float64 // degrees, where present
+	Pressure      float64 // hPa, where present
+	CloudCover    float64 // percent, where present
+	Precipitation float64 // mm or probability percent, depending on the provider
+	Sunrise       time.Time
+	Sunset        time.Time
+	Conditions    string // raw, provider-reported condition text
+	Condition     conditions.Code
+	IsDay         bool
+	Icon          string // provider-reported icon/condition code
+}
+
+// Forecast is the normalized response returned by every Provider
+// implementation. Hourly and Minutely may be empty if the provider
+// doesn't offer that resolution.
+type Forecast struct {
+	Daily    []DataPoint
+	Hourly   []DataPoint
+	Minutely []DataPoint
+	// Stale is true when the forecast came from the on-disk cache
+	// because the live request failed (e.g. the network is down).
+	Stale bool
+}
+
+// Provider fetches a normalized Forecast for a given location.
+type Provider interface {
+	// Name returns the provider's registry name.
+	Name() string
+	// Fetch returns the forecast for city, which may be a place name or,
+	// depending on the provider, a "lat,lon" coordinate pair.
+	Fetch(city string) (Forecast, error)
+}
+
+// Factory builds a Provider configured with the given API key.
+type Factory func(apiKey string) Provider
+
+var registry = map[string]Factory{}
+
+// RegisterProvider makes a provider available under name via New. It is
+// intended to be called from the init function of each provider's file.
+func RegisterProvider(name string, factory Factory) {
+	registry[name] = factory
+}
+
+// New looks up the provider registered under name and constructs it with
+// the given API key.
+func New(name, apiKey string) (Provider, error) {
+	factory, ok := registry[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown weather provider %q", name)
+	}
+	return factory(apiKey), nil
+}
+
+// Names returns the names of all registered providers.
+func Names() []string {
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	return names
+}
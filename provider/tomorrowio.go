@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/uduakabaci/charm-go-weather/conditions"
+)
+
+func init() {
+	RegisterProvider("tomorrowio", func(apiKey string) Provider {
+		return &tomorrowIO{apiKey: apiKey, baseURL: "https://api.tomorrow.io/v4/weather/forecast"}
+	})
+}
+
+// tomorrowIO talks to the Tomorrow.io weather forecast endpoint.
+type tomorrowIO struct {
+	apiKey  string
+	baseURL string
+}
+
+func (p *tomorrowIO) Name() string { return "tomorrowio" }
+
+type tomorrowIODailyValues struct {
+	TemperatureAvg              float64 `json:"temperatureAvg"`
+	HumidityAvg                 float64 `json:"humidityAvg"`
+	WindSpeedAvg                float64 `json:"windSpeedAvg"`
+	WindDirectionAvg            float64 `json:"windDirectionAvg"`
+	PressureSurfaceLevelAvg     float64 `json:"pressureSurfaceLevelAvg"`
+	CloudCoverAvg               float64 `json:"cloudCoverAvg"`
+	PrecipitationProbabilityAvg float64 `json:"precipitationProbabilityAvg"`
+	WeatherCodeMax              int     `json:"weatherCodeMax"`
+	SunriseTime                 string  `json:"sunriseTime"`
+	SunsetTime                  string  `json:"sunsetTime"`
+}
+
+type tomorrowIOIntervalValues struct {
+	Temperature              float64 `json:"temperature"`
+	Humidity                 float64 `json:"humidity"`
+	WindSpeed                float64 `json:"windSpeed"`
+	WindDirection            float64 `json:"windDirection"`
+	PressureSurfaceLevel     float64 `json:"pressureSurfaceLevel"`
+	CloudCover               float64 `json:"cloudCover"`
+	PrecipitationProbability float64 `json:"precipitationProbability"`
+	WeatherCode              int     `json:"weatherCode"`
+}
+
+type tomorrowIOResponse struct {
+	Timelines struct {
+		Daily []struct {
+			Time   string                `json:"time"`
+			Values tomorrowIODailyValues `json:"values"`
+		} `json:"daily"`
+		Hourly []struct {
+			Time   string                   `json:"time"`
+			Values tomorrowIOIntervalValues `json:"values"`
+		} `json:"hourly"`
+		Minutely []struct {
+			Time   string                   `json:"time"`
+			Values tomorrowIOIntervalValues `json:"values"`
+		} `json:"minutely"`
+	} `json:"timelines"`
+}
+
+func (p *tomorrowIO) Fetch(city string) (Forecast, error) {
+	if p.apiKey == "" {
+		return Forecast{}, fmt.Errorf("tomorrowio: missing API key, set --api-key or TOMORROWIO_API_KEY")
+	}
+
+	reqURL := p.baseURL + "?location=" + url.QueryEscape(city) + "&apikey=" + url.QueryEscape(p.apiKey)
+	result, err := fetchWithCache(p.Name(), normalizeKey(city), reqURL, nil)
+	if err != nil {
+		return Forecast{}, err
+	}
+
+	var raw tomorrowIOResponse
+	if err := json.Unmarshal(result.body, &raw); err != nil {
+		return Forecast{}, err
+	}
+
+	forecast := Forecast{}
+	for _, day := range raw.Timelines.Daily {
+		t := parseTomorrowTime(day.Time)
+		forecast.Daily = append(forecast.Daily, DataPoint{
+			Time:          t,
+			Temperature:   day.Values.TemperatureAvg,
+			Humidity:      day.Values.HumidityAvg,
+			WindSpeed:     day.Values.WindSpeedAvg,
+			WindDirection: day.Values.WindDirectionAvg,
+			Pressure:      day.Values.PressureSurfaceLevelAvg,
+			CloudCover:    day.Values.CloudCoverAvg,
+			Precipitation: day.Values.PrecipitationProbabilityAvg,
+			Sunrise:       parseTomorrowTime(day.Values.SunriseTime),
+			Sunset:        parseTomorrowTime(day.Values.SunsetTime),
+			Icon:          fmt.Sprintf("%d", day.Values.WeatherCodeMax),
+			Condition:     tomorrowCondition(day.Values.WeatherCodeMax),
+			IsDay:         isDaytime(t),
+		})
+	}
+	for _, hour := range raw.Timelines.Hourly {
+		t := parseTomorrowTime(hour.Time)
+		forecast.Hourly = append(forecast.Hourly, DataPoint{
+			Time:          t,
+			Temperature:   hour.Values.Temperature,
+			Humidity:      hour.Values.Humidity,
+			WindSpeed:     hour.Values.WindSpeed,
+			WindDirection: hour.Values.WindDirection,
+			Pressure:      hour.Values.PressureSurfaceLevel,
+			CloudCover:    hour.Values.CloudCover,
+			Precipitation: hour.Values.PrecipitationProbability,
+			Icon:          fmt.Sprintf("%d", hour.Values.WeatherCode),
+			Condition:     tomorrowCondition(hour.Values.WeatherCode),
+			IsDay:         isDaytime(t),
+		})
+	}
+	for _, minute := range raw.Timelines.Minutely {
+		t := parseTomorrowTime(minute.Time)
+		forecast.Minutely = append(forecast.Minutely, DataPoint{
+			Time:          t,
+			Temperature:   minute.Values.Temperature,
+			Humidity:      minute.Values.Humidity,
+			WindSpeed:     minute.Values.WindSpeed,
+			WindDirection: minute.Values.WindDirection,
+			Pressure:      minute.Values.PressureSurfaceLevel,
+			CloudCover:    minute.Values.CloudCover,
+			Precipitation: minute.Values.PrecipitationProbability,
+			Condition:     tomorrowCondition(minute.Values.WeatherCode),
+			IsDay:         isDaytime(t),
+		})
+	}
+	forecast.Stale = result.stale
+
+	return forecast, nil
+}
+
+func parseTomorrowTime(s string) time.Time {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// isDaytime is a rough fallback for providers whose codes don't encode
+// day/night directly.
+func isDaytime(t time.Time) bool {
+	hour := t.Hour()
+	return hour >= 6 && hour < 18
+}
+
+// tomorrowCondition maps a Tomorrow.io weather code to a normalized
+// condition. See https://docs.tomorrow.io/reference/data-layers-weather-codes.
+func tomorrowCondition(code int) conditions.Code {
+	switch {
+	case code == 1000:
+		return conditions.Clear
+	case code == 1100 || code == 1101 || code == 1102:
+		return conditions.PartlyCloudy
+	case code == 1001:
+		return conditions.Overcast
+	case code == 2000 || code == 2100:
+		return conditions.Fog
+	case code == 4000 || code == 6000 || code == 6200 || code == 6201 || code == 6001:
+		return conditions.Drizzle
+	case code == 4001 || code == 4200 || code == 4201:
+		return conditions.Rain
+	case code == 5000 || code == 5001 || code == 5100 || code == 5101 || code == 7000 || code == 7101 || code == 7102:
+		return conditions.Snow
+	case code == 8000:
+		return conditions.Thunderstorm
+	default:
+		return conditions.Unknown
+	}
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/uduakabaci/charm-go-weather/conditions"
+)
+
+func TestTomorrowIOFetch(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("apikey") != "test-key" {
+			t.Errorf("expected apikey=test-key, got %q", r.URL.Query().Get("apikey"))
+		}
+		w.Write([]byte(`{
+			"timelines": {
+				"daily": [
+					{"time": "2024-01-01T00:00:00Z", "values": {"temperatureAvg": 21.5, "humidityAvg": 60, "windSpeedAvg": 3.2, "windDirectionAvg": 180, "pressureSurfaceLevelAvg": 1013.2, "cloudCoverAvg": 20, "precipitationProbabilityAvg": 10, "weatherCodeMax": 1000, "sunriseTime": "2024-01-01T06:15:00Z", "sunsetTime": "2024-01-01T18:30:00Z"}}
+				],
+				"hourly": [
+					{"time": "2024-01-01T00:00:00Z", "values": {"temperature": 20.1, "humidity": 58, "windSpeed": 2.9, "weatherCode": 1000}},
+					{"time": "2024-01-01T01:00:00Z", "values": {"temperature": 19.8, "humidity": 59, "windSpeed": 3.0, "weatherCode": 1000}}
+				],
+				"minutely": [
+					{"time": "2024-01-01T00:00:00Z", "values": {"temperature": 20.1, "humidity": 58, "windSpeed": 2.9}}
+				]
+			}
+		}`))
+	}))
+	defer srv.Close()
+
+	p := &tomorrowIO{apiKey: "test-key", baseURL: srv.URL}
+
+	forecast, err := p.Fetch("Uyo")
+	if err != nil {
+		t.Fatalf("Fetch returned error: %v", err)
+	}
+	if len(forecast.Daily) != 1 {
+		t.Fatalf("expected 1 daily entry, got %d", len(forecast.Daily))
+	}
+	if forecast.Daily[0].Temperature != 21.5 {
+		t.Errorf("expected temperature 21.5, got %v", forecast.Daily[0].Temperature)
+	}
+	if forecast.Daily[0].Icon != "1000" {
+		t.Errorf("expected icon 1000, got %q", forecast.Daily[0].Icon)
+	}
+	if forecast.Daily[0].Condition != conditions.Clear {
+		t.Errorf("expected condition Clear, got %q", forecast.Daily[0].Condition)
+	}
+	if forecast.Daily[0].Sunrise.IsZero() || forecast.Daily[0].Sunset.IsZero() {
+		t.Error("expected non-zero sunrise/sunset")
+	}
+	if len(forecast.Hourly) != 2 {
+		t.Fatalf("expected 2 hourly entries, got %d", len(forecast.Hourly))
+	}
+	if len(forecast.Minutely) != 1 {
+		t.Fatalf("expected 1 minutely entry, got %d", len(forecast.Minutely))
+	}
+}
+
+func TestTomorrowIOFetchMissingAPIKey(t *testing.T) {
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	p := &tomorrowIO{}
+	if _, err := p.Fetch("Uyo"); err == nil {
+		t.Fatal("expected an error when the API key is missing")
+	}
+}